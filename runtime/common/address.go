@@ -20,12 +20,19 @@ package common
 
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
+
+	"golang.org/x/crypto/sha3"
 )
 
 const AddressLength = 8
 
+// ErrInvalidAddressChecksum is returned by ChecksumHexToAddress when the
+// checksum encoded in a mixed-case hex string does not match the address.
+var ErrInvalidAddressChecksum = errors.New("invalid address checksum")
+
 type Address [AddressLength]byte
 
 // BytesToAddress returns Address with value b.
@@ -46,6 +53,46 @@ func (a Address) String() string {
 	return a.Hex()
 }
 
+// ChecksumHex returns a checksummed hex string representation of the address,
+// mirroring the mixed-case checksum scheme popularized for account addresses
+// on other chains, adapted to Flow's 8-byte address width: each hex nibble of
+// a.Hex() is upper-cased if the corresponding nibble of
+// SHA3-256(a.Hex()) is >= 8, and lower-cased otherwise. Digits are unaffected,
+// since they have no letter case.
+//
+// On-chain serialization is unaffected by this encoding; it exists purely to
+// help tooling and users catch typos in displayed or entered addresses.
+func (a Address) ChecksumHex() string {
+	lower := a.Hex()
+
+	hash := sha3.Sum256([]byte(lower))
+
+	checksummed := make([]byte, len(lower))
+	for i := 0; i < len(lower); i++ {
+		c := lower[i]
+		if c < 'a' || c > 'f' {
+			checksummed[i] = c
+			continue
+		}
+
+		hashByte := hash[i/2]
+		var nibble byte
+		if i%2 == 0 {
+			nibble = hashByte >> 4
+		} else {
+			nibble = hashByte & 0x0f
+		}
+
+		if nibble >= 8 {
+			checksummed[i] = c - ('a' - 'A')
+		} else {
+			checksummed[i] = c
+		}
+	}
+
+	return string(checksummed)
+}
+
 // SetBytes sets the address to the value of b.
 //
 // If b is larger than len(a) it will panic.
@@ -99,3 +146,45 @@ func HexToAddress(h string) (Address, error) {
 	}
 	return BytesToAddress(b), nil
 }
+
+// ChecksumHexToAddress converts a hex string, with or without a checksum, to
+// an Address. If the string contains any uppercase letter, it is treated as
+// checksummed and validated against the decoded address's ChecksumHex,
+// returning ErrInvalidAddressChecksum on a mismatch.
+func ChecksumHexToAddress(h string) (Address, error) {
+	address, err := HexToAddress(h)
+	if err != nil {
+		return Address{}, err
+	}
+
+	trimmed := strings.TrimPrefix(h, "0x")
+	if len(trimmed)%2 == 1 {
+		trimmed = "0" + trimmed
+	}
+
+	if !containsUpperHex(trimmed) {
+		return address, nil
+	}
+
+	expected := address.ChecksumHex()
+	if len(expected) > len(trimmed) {
+		expected = expected[len(expected)-len(trimmed):]
+	}
+
+	if expected != trimmed {
+		return Address{}, ErrInvalidAddressChecksum
+	}
+
+	return address, nil
+}
+
+// containsUpperHex returns true if s contains any uppercase hex letter.
+func containsUpperHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'F' {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package common
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddress_ChecksumHex(t *testing.T) {
+
+	t.Parallel()
+
+	address := BytesToAddress([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08})
+
+	checksummed := address.ChecksumHex()
+
+	assert.Equal(t, address.Hex(), strings.ToLower(checksummed))
+	assert.Len(t, checksummed, len(address.Hex()))
+}
+
+func TestChecksumHexToAddress(t *testing.T) {
+
+	t.Parallel()
+
+	t.Run("round-trip", func(t *testing.T) {
+		t.Parallel()
+
+		address := BytesToAddress([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08})
+
+		decoded, err := ChecksumHexToAddress(address.ChecksumHex())
+		require.NoError(t, err)
+		assert.Equal(t, address, decoded)
+	})
+
+	t.Run("round-trip with leading zeros", func(t *testing.T) {
+		t.Parallel()
+
+		address := BytesToAddress([]byte{0x00, 0x00, 0xab, 0xcd, 0xef, 0x12, 0x34, 0x56})
+
+		short := address.ShortHexWithPrefix()
+
+		trimmed := strings.TrimPrefix(short, "0x")
+		checksummed := address.ChecksumHex()
+		checksummedShort := "0x" + checksummed[len(checksummed)-len(trimmed):]
+
+		// Guard against a vacuous test: the checksummed short form must
+		// actually contain an uppercase hex letter, or ChecksumHexToAddress
+		// would take the plain, unchecksummed early-return path below and
+		// never validate anything.
+		require.True(t, containsUpperHex(checksummedShort), "test address has no letter nibbles to checksum")
+
+		decoded, err := ChecksumHexToAddress(checksummedShort)
+		require.NoError(t, err)
+		assert.Equal(t, address, decoded)
+	})
+
+	t.Run("plain lowercase hex is accepted without a checksum", func(t *testing.T) {
+		t.Parallel()
+
+		address := BytesToAddress([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08})
+
+		decoded, err := ChecksumHexToAddress(address.Hex())
+		require.NoError(t, err)
+		assert.Equal(t, address, decoded)
+	})
+
+	t.Run("invalid checksum", func(t *testing.T) {
+		t.Parallel()
+
+		// The checksum of this address mixes upper- and lowercase letters
+		// ("ab12CD34ef56AB78"), so the flipped string below still contains an
+		// uppercase letter and is validated as checksummed rather than being
+		// accepted as plain lowercase hex.
+		address := BytesToAddress([]byte{0xab, 0x12, 0xcd, 0x34, 0xef, 0x56, 0xab, 0x78})
+
+		checksummed := address.ChecksumHex()
+
+		// Flip the case of every character, which cannot simultaneously be a
+		// valid checksum, since flipping the case of a correctly-checksummed
+		// string changes at least one nibble's case relative to the hash.
+		flipped := strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'f':
+				return r - ('a' - 'A')
+			case r >= 'A' && r <= 'F':
+				return r + ('a' - 'A')
+			default:
+				return r
+			}
+		}, checksummed)
+
+		_, err := ChecksumHexToAddress(flipped)
+		require.ErrorIs(t, err, ErrInvalidAddressChecksum)
+	})
+
+	t.Run("invalid hex", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ChecksumHexToAddress("0xzz")
+		require.Error(t, err)
+	})
+}
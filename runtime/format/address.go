@@ -0,0 +1,64 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package format renders Cadence runtime values for display by external
+// tooling (e.g. CLIs, wallets, block explorers) built against this module.
+// It intentionally has no callers inside the runtime itself: interpreter
+// error messages and other internal uses of common.Address.String go
+// through Hex unchanged, since the original request that added
+// Address.ChecksumHex required existing Hex/String/HexToAddress behavior to
+// be preserved as-is.
+package format
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// AddressFormattingOption configures Address.
+type AddressFormattingOption func(*addressFormattingConfig)
+
+type addressFormattingConfig struct {
+	checksummed bool
+}
+
+// WithChecksummedAddresses causes Address to render addresses using
+// common.Address.ChecksumHex instead of common.Address.Hex. This only affects
+// how addresses are displayed; on-chain serialization of addresses is
+// unaffected and stays byte-identical.
+func WithChecksummedAddresses() AddressFormattingOption {
+	return func(config *addressFormattingConfig) {
+		config.checksummed = true
+	}
+}
+
+// Address formats an address as a "0x"-prefixed hex string for display by
+// external tooling. Tooling that wants typo-resistant output can opt in with
+// WithChecksummedAddresses; the default matches the plain, unchecksummed
+// rendering used everywhere else in the runtime.
+func Address(address common.Address, options ...AddressFormattingOption) string {
+	var config addressFormattingConfig
+	for _, option := range options {
+		option(&config)
+	}
+
+	if config.checksummed {
+		return "0x" + address.ChecksumHex()
+	}
+
+	return "0x" + address.Hex()
+}
@@ -0,0 +1,52 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestAddress(t *testing.T) {
+
+	t.Parallel()
+
+	address := common.BytesToAddress([]byte{0xab, 0xcd, 0xef, 0x12, 0x34, 0x56, 0x78, 0x9a})
+
+	t.Run("default is plain hex", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "0x"+address.Hex(), Address(address))
+	})
+
+	t.Run("WithChecksummedAddresses opts into checksummed hex", func(t *testing.T) {
+		t.Parallel()
+
+		formatted := Address(address, WithChecksummedAddresses())
+
+		assert.Equal(t, "0x"+address.ChecksumHex(), formatted)
+		// The checksummed rendering is only a display concern: the underlying
+		// bytes, and therefore on-chain serialization, are unaffected.
+		assert.True(t, strings.EqualFold(formatted, Address(address)))
+	})
+}
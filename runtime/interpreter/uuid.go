@@ -0,0 +1,57 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package interpreter
+
+import (
+	"github.com/onflow/cadence/runtime/common"
+)
+
+// UUIDProvider generates the UUIDs assigned to resources as they are created.
+//
+// Next returns the UUID to assign to a resource created within the given location.
+// Reserve allocates a contiguous batch of n UUIDs in a single call and returns the
+// first one in the batch; the caller is expected to hand out the remaining n-1 values
+// sequentially without calling back into the provider.
+type UUIDProvider interface {
+	Next(location common.Location) (uint64, error)
+	Reserve(n int) (uint64, error)
+}
+
+// WithUUIDProvider returns an interpreter option which sets the given UUIDProvider
+// as the source of UUIDs assigned to newly created resources.
+//
+// This is a separate, additive option: it does not change WithUUIDHandler, which
+// continues to take a bare func() (uint64, error) for existing callers.
+//
+// Out of scope: per-contract/per-account sharding via provider.Next's location
+// parameter is only accurate for the top-level Interpreter that WithUUIDProvider
+// was applied to. A sub-interpreter created for an imported contract (see
+// NewSubInterpreter) copies the parent's uuidHandler closure as-is rather than
+// re-applying interpreter options, so it keeps reporting the top-level Location
+// that was captured here, not its own. Making every sub-interpreter report its
+// own Location would require changing how sub-interpreters acquire their
+// uuidHandler, which is out of scope for this change.
+func WithUUIDProvider(provider UUIDProvider) Option {
+	return func(interpreter *Interpreter) error {
+		interpreter.uuidHandler = func() (uint64, error) {
+			return provider.Next(interpreter.Location)
+		}
+		return nil
+	}
+}
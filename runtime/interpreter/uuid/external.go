@@ -0,0 +1,90 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package uuid
+
+import (
+	"sync"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// ExternalBackend is implemented by out-of-process sources of UUIDs, such as a
+// KMS or secret store that hands out monotonic counters per contract or
+// account, mirroring the local vs. remote split used by pluggable secret
+// managers. Allocate reserves n sequential UUIDs for location and returns the
+// first one in the batch.
+type ExternalBackend interface {
+	Allocate(location common.Location, n int) (uint64, error)
+}
+
+// ExternalProvider is a UUIDProvider backed by an ExternalBackend. It batches
+// calls to the backend by reserving reserveSize UUIDs at a time per location
+// and handing them out locally until the batch is exhausted.
+type ExternalProvider struct {
+	mu          sync.Mutex
+	backend     ExternalBackend
+	reserveSize int
+	batches     map[common.Location]*batch
+}
+
+type batch struct {
+	next uint64
+	end  uint64
+}
+
+var _ interpreter.UUIDProvider = &ExternalProvider{}
+
+// NewExternalProvider returns an ExternalProvider that requests reserveSize
+// UUIDs from backend at a time. A reserveSize of 1 disables batching.
+func NewExternalProvider(backend ExternalBackend, reserveSize int) *ExternalProvider {
+	if reserveSize < 1 {
+		reserveSize = 1
+	}
+	return &ExternalProvider{
+		backend:     backend,
+		reserveSize: reserveSize,
+		batches:     map[common.Location]*batch{},
+	}
+}
+
+func (p *ExternalProvider) Next(location common.Location) (uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	b, ok := p.batches[location]
+	if !ok || b.next >= b.end {
+		first, err := p.backend.Allocate(location, p.reserveSize)
+		if err != nil {
+			return 0, err
+		}
+		b = &batch{next: first, end: first + uint64(p.reserveSize)}
+		p.batches[location] = b
+	}
+
+	uuid := b.next
+	b.next++
+	return uuid, nil
+}
+
+// Reserve allocates n UUIDs directly from the backend, bypassing the
+// per-location batches used by Next.
+func (p *ExternalProvider) Reserve(n int) (uint64, error) {
+	return p.backend.Allocate(nil, n)
+}
@@ -0,0 +1,156 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package uuid
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// FileProvider is a UUIDProvider that persists its counter to a file, so that
+// the next process to open the same file recovers the counter instead of
+// reusing UUIDs that have already been handed out.
+type FileProvider struct {
+	mu   sync.Mutex
+	path string
+	next uint64
+}
+
+var _ interpreter.UUIDProvider = &FileProvider{}
+
+// NewFileProvider recovers the counter from path, if it exists, and is ready
+// to persist to it otherwise.
+func NewFileProvider(path string) (*FileProvider, error) {
+	next, err := recoverCounter(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileProvider{
+		path: path,
+		next: next,
+	}, nil
+}
+
+func recoverCounter(path string) (uint64, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read UUID counter file: %w", err)
+	}
+
+	if len(contents) == 0 {
+		return 0, nil
+	}
+
+	next, err := strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to recover UUID counter: %w", err)
+	}
+
+	return next, nil
+}
+
+func (p *FileProvider) Next(_ common.Location) (uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	uuid := p.next
+	if err := p.persist(uuid + 1); err != nil {
+		return 0, err
+	}
+	p.next = uuid + 1
+	return uuid, nil
+}
+
+func (p *FileProvider) Reserve(n int) (uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	first := p.next
+	next := first + uint64(n)
+	if err := p.persist(next); err != nil {
+		return 0, err
+	}
+	p.next = next
+	return first, nil
+}
+
+// persist durably records next. It writes to a temporary file in the same
+// directory as p.path, fsyncs it, renames it over p.path, and fsyncs the
+// directory so the rename itself survives a crash. Truncating p.path in
+// place before writing would leave a window, if the process crashes between
+// the truncate and the fsync, where the counter file reads as empty and
+// recoverCounter would reissue every UUID already handed out; skipping the
+// directory fsync after the rename leaves a narrower but equally real
+// window, where the rename's directory-entry update is lost and p.path
+// resolves back to its smaller, previous counter value after a crash.
+func (p *FileProvider) persist(next uint64) error {
+	tmp := p.path + ".tmp"
+
+	file, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to persist UUID counter: %w", err)
+	}
+
+	_, writeErr := file.WriteString(strconv.FormatUint(next, 10))
+	syncErr := file.Sync()
+	closeErr := file.Close()
+
+	switch {
+	case writeErr != nil:
+		return fmt.Errorf("failed to persist UUID counter: %w", writeErr)
+	case syncErr != nil:
+		return fmt.Errorf("failed to persist UUID counter: %w", syncErr)
+	case closeErr != nil:
+		return fmt.Errorf("failed to persist UUID counter: %w", closeErr)
+	}
+
+	if err := os.Rename(tmp, p.path); err != nil {
+		return fmt.Errorf("failed to persist UUID counter: %w", err)
+	}
+
+	return syncDir(filepath.Dir(p.path))
+}
+
+// syncDir fsyncs dir so that a preceding rename of one of its entries is
+// durable: on most POSIX filesystems, a rename only updates the in-memory
+// directory entry until the directory itself is fsynced.
+func syncDir(dir string) error {
+	dirFile, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to persist UUID counter: %w", err)
+	}
+	defer dirFile.Close()
+
+	if err := dirFile.Sync(); err != nil {
+		return fmt.Errorf("failed to persist UUID counter: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package uuid provides pluggable backends for interpreter.UUIDProvider.
+package uuid
+
+import (
+	"sync"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+)
+
+// MemoryProvider is a UUIDProvider backed by an in-memory monotonic counter.
+// It matches the behavior interpreters had before UUIDProvider was introduced:
+// UUIDs are not preserved across process restarts.
+type MemoryProvider struct {
+	mu   sync.Mutex
+	next uint64
+}
+
+var _ interpreter.UUIDProvider = &MemoryProvider{}
+
+// NewMemoryProvider returns a MemoryProvider whose counter starts at start.
+func NewMemoryProvider(start uint64) *MemoryProvider {
+	return &MemoryProvider{next: start}
+}
+
+func (p *MemoryProvider) Next(_ common.Location) (uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	uuid := p.next
+	p.next++
+	return uuid, nil
+}
+
+func (p *MemoryProvider) Reserve(n int) (uint64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	first := p.next
+	p.next += uint64(n)
+	return first, nil
+}
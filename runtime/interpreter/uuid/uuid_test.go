@@ -0,0 +1,181 @@
+/*
+ * Cadence - The resource-oriented smart contract programming language
+ *
+ * Copyright 2019-2021 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package uuid
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/cadence/runtime/common"
+)
+
+func TestFileProviderRecoversAfterRestart(t *testing.T) {
+
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "uuid-counter")
+
+	provider, err := NewFileProvider(path)
+	require.NoError(t, err)
+
+	for i := uint64(0); i < 10; i++ {
+		uuid, err := provider.Next(nil)
+		require.NoError(t, err)
+		require.Equal(t, i, uuid)
+	}
+
+	restarted, err := NewFileProvider(path)
+	require.NoError(t, err)
+
+	uuid, err := restarted.Next(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), uuid)
+}
+
+func TestFileProviderRecoversFromInterruptedPersist(t *testing.T) {
+
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "uuid-counter")
+
+	provider, err := NewFileProvider(path)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		_, err := provider.Next(nil)
+		require.NoError(t, err)
+	}
+
+	// Simulate a crash that lands after persist has opened and partially
+	// written its temporary file, but before that file was renamed into
+	// place: the counter file itself must be untouched by a stray, unrenamed
+	// temp file, so recovery must not reissue any of the 5 UUIDs already
+	// handed out.
+	require.NoError(t, os.WriteFile(path+".tmp", []byte("garbage"), 0600))
+
+	restarted, err := NewFileProvider(path)
+	require.NoError(t, err)
+
+	uuid, err := restarted.Next(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), uuid)
+}
+
+func TestFileProviderReserveIsContiguous(t *testing.T) {
+
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "uuid-counter")
+
+	provider, err := NewFileProvider(path)
+	require.NoError(t, err)
+
+	first, err := provider.Reserve(100)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), first)
+
+	next, err := provider.Next(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), next)
+}
+
+func TestMemoryProviderReserveIsContiguous(t *testing.T) {
+
+	t.Parallel()
+
+	provider := NewMemoryProvider(0)
+
+	first, err := provider.Reserve(1000)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), first)
+
+	next, err := provider.Next(nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), next)
+}
+
+func TestMemoryProviderConcurrentNextDoesNotRepeat(t *testing.T) {
+
+	t.Parallel()
+
+	const goroutines = 50
+	const perGoroutine = 100
+
+	provider := NewMemoryProvider(0)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[uint64]bool, goroutines*perGoroutine)
+
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				uuid, err := provider.Next(nil)
+				require.NoError(t, err)
+
+				mu.Lock()
+				require.False(t, seen[uuid], "UUID %d handed out more than once", uuid)
+				seen[uuid] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, seen, goroutines*perGoroutine)
+}
+
+type stubBackend struct {
+	mu   sync.Mutex
+	next uint64
+	n    int
+}
+
+func (b *stubBackend) Allocate(_ common.Location, n int) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.n++
+	first := b.next
+	b.next += uint64(n)
+	return first, nil
+}
+
+func TestExternalProviderBatchesAllocations(t *testing.T) {
+
+	t.Parallel()
+
+	backend := &stubBackend{}
+	provider := NewExternalProvider(backend, 10)
+
+	for i := uint64(0); i < 25; i++ {
+		uuid, err := provider.Next(nil)
+		require.NoError(t, err)
+		require.Equal(t, i, uuid)
+	}
+
+	// 25 UUIDs at a batch size of 10 requires 3 calls to the backend.
+	require.Equal(t, 3, backend.n)
+}